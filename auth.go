@@ -0,0 +1,31 @@
+package main
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// authMiddleware requires a valid "Authorization: Bearer <key>" header on
+// every request except "/", "/healthz", and "/readyz", which need to stay
+// reachable for basic probing and load-balancer health/readiness checks. An
+// empty apiKey disables the check entirely, which keeps local/dev usage (no
+// flag, no env var) as simple as it was before.
+func authMiddleware(apiKey string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if apiKey == "" || r.URL.Path == "/" || r.URL.Path == "/healthz" || r.URL.Path == "/readyz" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		header := r.Header.Get("Authorization")
+		key, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(key), []byte(apiKey)) != 1 {
+			reqID := requestIDFromContext(r.Context())
+			writeErrorJSON(w, http.StatusUnauthorized, "unauthorized", "missing or invalid API key", reqID)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
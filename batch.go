@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// batchResult is the JSON-friendly form of a BatchOp, with an HTTP status
+// per operation instead of a Go error.
+type batchResult struct {
+	Op     string `json:"op"`
+	Target string `json:"target"`
+	Status int    `json:"status"`
+	Item   *Item  `json:"item,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// batchHandler applies every add/complete/delete operation in the request
+// body under a single Store.Batch call, so a client no longer pays one HTTP
+// round trip (and, for jsonStore, one lock/save cycle) per item.
+func batchHandler(w http.ResponseWriter, r *http.Request, store Store, hub *eventHub, maxBodyBytes int64) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+
+	var req BatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		replyError(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid JSON: %v", err))
+		return
+	}
+
+	ops, err := store.Batch(req)
+	if err != nil {
+		replyError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	results := make([]batchResult, len(ops))
+	for i, op := range ops {
+		results[i] = toBatchResult(op)
+		if op.Err == nil {
+			hub.publish(toBatchEvent(op))
+		}
+	}
+
+	replyBatchResults(w, r, results)
+}
+
+func toBatchResult(op BatchOp) batchResult {
+	res := batchResult{Op: op.Op, Target: op.Target}
+
+	if op.Err != nil {
+		res.Error = op.Err.Error()
+		if errors.Is(op.Err, ErrNoFound) {
+			res.Status = http.StatusNotFound
+		} else {
+			res.Status = http.StatusBadRequest
+		}
+		return res
+	}
+
+	if op.Op == "add" {
+		res.Status = http.StatusCreated
+	} else {
+		res.Status = http.StatusOK
+	}
+
+	item := op.Item
+	res.Item = &item
+
+	return res
+}
+
+func toBatchEvent(op BatchOp) todoEvent {
+	switch op.Op {
+	case "add":
+		return todoEvent{Type: "added", Item: op.Item}
+	case "complete":
+		return todoEvent{Type: "completed", Item: op.Item}
+	default:
+		id, _ := strconv.Atoi(op.Target)
+		return todoEvent{Type: "deleted", Item: Item{ID: id}}
+	}
+}
+
+// replyBatchResults replies 207 Multi-Status with the per-operation results,
+// mirroring how WebDAV reports mixed outcomes for a batch of sub-requests.
+func replyBatchResults(w http.ResponseWriter, r *http.Request, results []batchResult) {
+	body, err := json.Marshal(struct {
+		Results []batchResult `json:"results"`
+	}{Results: results})
+	if err != nil {
+		replyError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusMultiStatus)
+	w.Write(body)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestBatchHandlerAddCompleteDelete walks POST /todo/batch through an
+// add/complete/delete mix, including Delete IDs given out of order, which
+// regressed the positional jsonStore in 6473159 (fixed in d702369): deleting
+// [2,4] against [A,B,C,D,E] in request order resolves the second delete
+// against a list that has already shifted, removing E instead of D.
+func TestBatchHandlerAddCompleteDelete(t *testing.T) {
+	h := newTestMux(t)
+
+	for _, task := range []string{"A", "B", "C", "D", "E"} {
+		rec := doRequest(h, http.MethodPost, "/todo", `{"task":"`+task+`"}`)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("POST /todo %q: want %d, got %d: %s", task, http.StatusCreated, rec.Code, rec.Body)
+		}
+	}
+
+	rec := doRequest(h, http.MethodPost, "/todo/batch", `{"complete":[1],"delete":[2,4]}`)
+	if rec.Code != http.StatusMultiStatus {
+		t.Fatalf("POST /todo/batch: want %d, got %d: %s", http.StatusMultiStatus, rec.Code, rec.Body)
+	}
+
+	rec = doRequest(h, http.MethodGet, "/todo", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /todo: want %d, got %d: %s", http.StatusOK, rec.Code, rec.Body)
+	}
+
+	body := rec.Body.String()
+	for _, want := range []string{`"task":"A"`, `"task":"C"`, `"task":"E"`} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("GET /todo: expected %s to survive the batch delete: %s", want, body)
+		}
+	}
+	for _, unwanted := range []string{`"task":"B"`, `"task":"D"`} {
+		if strings.Contains(body, unwanted) {
+			t.Fatalf("GET /todo: expected %s to be gone after the batch delete: %s", unwanted, body)
+		}
+	}
+	if !strings.Contains(body, `"done":true`) {
+		t.Fatalf("GET /todo: expected completed item to show done:true: %s", body)
+	}
+}
+
+// TestBatchHandlerUnknownIDDoesNotSinkBatch asserts one bad ID in a batch
+// reports its own error without failing the rest of the operations.
+func TestBatchHandlerUnknownIDDoesNotSinkBatch(t *testing.T) {
+	h := newTestMux(t)
+
+	rec := doRequest(h, http.MethodPost, "/todo", `{"task":"only item"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST /todo: want %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body)
+	}
+
+	rec = doRequest(h, http.MethodPost, "/todo/batch", `{"complete":[1,99]}`)
+	if rec.Code != http.StatusMultiStatus {
+		t.Fatalf("POST /todo/batch: want %d, got %d: %s", http.StatusMultiStatus, rec.Code, rec.Body)
+	}
+
+	body := rec.Body.String()
+	if !strings.Contains(body, `"status":200`) {
+		t.Fatalf("POST /todo/batch: expected the valid complete to succeed: %s", body)
+	}
+	if !strings.Contains(body, `"status":404`) {
+		t.Fatalf("POST /todo/batch: expected the unknown ID to report 404: %s", body)
+	}
+}
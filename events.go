@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// todoEvent is published whenever a mutating handler changes the list.
+type todoEvent struct {
+	Type string `json:"type"` // "added", "completed", or "deleted"
+	Item Item   `json:"item"`
+}
+
+// eventHub is a small in-process pub/sub: each subscriber gets its own
+// buffered channel, and publish fans the event out to all of them without
+// blocking the publisher on a slow subscriber.
+type eventHub struct {
+	mu   sync.Mutex
+	subs map[chan todoEvent]struct{}
+}
+
+func newEventHub() *eventHub {
+	return &eventHub{subs: make(map[chan todoEvent]struct{})}
+}
+
+func (h *eventHub) subscribe() chan todoEvent {
+	ch := make(chan todoEvent, 16)
+
+	h.mu.Lock()
+	h.subs[ch] = struct{}{}
+	h.mu.Unlock()
+
+	return ch
+}
+
+func (h *eventHub) unsubscribe(ch chan todoEvent) {
+	h.mu.Lock()
+	delete(h.subs, ch)
+	h.mu.Unlock()
+
+	close(ch)
+}
+
+func (h *eventHub) publish(ev todoEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subs {
+		select {
+		case ch <- ev:
+		default: // a slow subscriber misses an event rather than stalling publish
+		}
+	}
+}
+
+// eventsHandler upgrades the connection to an SSE stream and forwards every
+// published todoEvent as a "data:" line until the client disconnects.
+func eventsHandler(hub *eventHub) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			replyError(w, r, http.StatusInternalServerError, "streaming not supported")
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		ch := hub.subscribe()
+		defer hub.unsubscribe(ch)
+
+		heartbeat := time.NewTicker(15 * time.Second)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case ev := <-ch:
+				body, err := json.Marshal(ev)
+				if err != nil {
+					continue
+				}
+				fmt.Fprintf(w, "data: %s\n\n", body)
+				flusher.Flush()
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": heartbeat\n\n")
+				flusher.Flush()
+			}
+		}
+	}
+}
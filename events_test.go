@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// TestEventHubPublishReachesSubscribers checks the basic pub/sub contract:
+// a subscriber sees an event published after it subscribes.
+func TestEventHubPublishReachesSubscribers(t *testing.T) {
+	hub := newEventHub()
+
+	ch := hub.subscribe()
+	defer hub.unsubscribe(ch)
+
+	hub.publish(todoEvent{Type: "added", Item: Item{ID: 1, Task: "subscribe test"}})
+
+	select {
+	case ev := <-ch:
+		if ev.Type != "added" || ev.Item.Task != "subscribe test" {
+			t.Fatalf("unexpected event: %+v", ev)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the published event")
+	}
+}
+
+// TestEventHubPublishDoesNotBlockOnSlowSubscriber checks that a subscriber
+// whose buffered channel is full doesn't stall the publisher - it just
+// misses the event.
+func TestEventHubPublishDoesNotBlockOnSlowSubscriber(t *testing.T) {
+	hub := newEventHub()
+
+	ch := hub.subscribe()
+	defer hub.unsubscribe(ch)
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 32; i++ {
+			hub.publish(todoEvent{Type: "added", Item: Item{ID: i}})
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("publish blocked instead of dropping events for the slow subscriber")
+	}
+}
+
+// TestEventHubUnsubscribeStopsDelivery checks that events published after
+// unsubscribe don't panic on a closed channel and aren't delivered.
+func TestEventHubUnsubscribeStopsDelivery(t *testing.T) {
+	hub := newEventHub()
+
+	ch := hub.subscribe()
+	hub.unsubscribe(ch)
+
+	hub.publish(todoEvent{Type: "added", Item: Item{ID: 1}})
+
+	if _, ok := <-ch; ok {
+		t.Fatal("expected the channel to be closed after unsubscribe")
+	}
+}
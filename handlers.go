@@ -6,9 +6,6 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
-	"sync"
-
-	"github.com/karapetianash/todo-cli"
 )
 
 var (
@@ -16,6 +13,16 @@ var (
 	ErrInvalidData = errors.New("invalid data")
 )
 
+// todoResponse is the JSON envelope returned by every /todo endpoint.
+// Total, Limit, and Offset are only meaningful for the paginated
+// getAllHandler response.
+type todoResponse struct {
+	Results []Item `json:"results"`
+	Total   int    `json:"total"`
+	Limit   int    `json:"limit"`
+	Offset  int    `json:"offset"`
+}
+
 func rootHandler(w http.ResponseWriter, r *http.Request) {
 	if r.URL.Path != "/" {
 		replyError(w, r, http.StatusNotFound, "")
@@ -27,24 +34,32 @@ func rootHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 // todoRouter dispatches appropriate replying function form incoming request
-func todoRouter(todoFile string, l sync.Locker) http.HandlerFunc {
+func todoRouter(store Store, hub *eventHub, maxBatchBodyBytes int64) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		list := &todo.List{}
-
-		l.Lock()
-		defer l.Unlock()
+		if r.URL.Path == "events" {
+			if r.Method != http.MethodGet {
+				replyError(w, r, http.StatusMethodNotAllowed, "Method not supported")
+				return
+			}
+			eventsHandler(hub)(w, r)
+			return
+		}
 
-		if err := list.Get(todoFile); err != nil {
-			replyError(w, r, http.StatusInternalServerError, err.Error())
+		if r.URL.Path == "batch" {
+			if r.Method != http.MethodPost {
+				replyError(w, r, http.StatusMethodNotAllowed, "Method not supported")
+				return
+			}
+			batchHandler(w, r, store, hub, maxBatchBodyBytes)
 			return
 		}
 
 		if r.URL.Path == "" {
 			switch r.Method {
 			case http.MethodGet:
-				getAllHandler(w, r, list)
+				getAllHandler(w, r, store)
 			case http.MethodPost:
-				addHandler(w, r, list, todoFile)
+				addHandler(w, r, store, hub)
 			default:
 				message := "Method not supported"
 				replyError(w, r, http.StatusMethodNotAllowed, message)
@@ -52,24 +67,19 @@ func todoRouter(todoFile string, l sync.Locker) http.HandlerFunc {
 			return
 		}
 
-		id, err := validateID(r.URL.Path, list)
+		id, err := validateID(r.URL.Path)
 		if err != nil {
-			if errors.Is(err, ErrNoFound) {
-				replyError(w, r, http.StatusNotFound, err.Error())
-				return
-			}
-
 			replyError(w, r, http.StatusBadRequest, err.Error())
 			return
 		}
 
 		switch r.Method {
 		case http.MethodGet:
-			getOneHandler(w, r, list, id)
+			getOneHandler(w, r, store, id)
 		case http.MethodDelete:
-			deleteHandler(w, r, list, id, todoFile)
+			deleteHandler(w, r, store, id, hub)
 		case http.MethodPatch:
-			pathHandler(w, r, list, id, todoFile)
+			pathHandler(w, r, store, id, hub)
 		default:
 			message := "Method not supported"
 			replyError(w, r, http.StatusMethodNotAllowed, message)
@@ -77,37 +87,83 @@ func todoRouter(todoFile string, l sync.Locker) http.HandlerFunc {
 	}
 }
 
-// getAllHandler obtains all to-do items
-func getAllHandler(w http.ResponseWriter, r *http.Request, list *todo.List) {
-	resp := &todoResponse{
-		Results: *list,
+// getAllHandler obtains to-do items, optionally filtered by "status",
+// ordered by "sort", and paginated via "limit"/"offset".
+func getAllHandler(w http.ResponseWriter, r *http.Request, store Store) {
+	items, err := store.GetAll()
+	if err != nil {
+		replyError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	q := r.URL.Query()
+
+	items, err = filterItems(items, q.Get("status"))
+	if err != nil {
+		replyError(w, r, http.StatusBadRequest, err.Error())
+		return
 	}
 
+	if err := sortItems(items, q.Get("sort")); err != nil {
+		replyError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	total := len(items)
+
+	limit, offset, err := paginationParams(q)
+	if err != nil {
+		replyError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, next := paginate(items, limit, offset)
+	if next >= 0 {
+		w.Header().Set("Link", nextLink(r, limit, next))
+	}
+
+	resp := &todoResponse{
+		Results: page,
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+	}
 	replyJSONContent(w, r, http.StatusOK, resp)
 }
 
 // getOneHandler replies with a single item
-func getOneHandler(w http.ResponseWriter, r *http.Request, list *todo.List, id int) {
-	resp := &todoResponse{
-		Results: (*list)[id-1 : id],
+func getOneHandler(w http.ResponseWriter, r *http.Request, store Store, id int) {
+	item, err := store.GetByID(id)
+	if err != nil {
+		if errors.Is(err, ErrNoFound) {
+			replyError(w, r, http.StatusNotFound, err.Error())
+			return
+		}
+		replyError(w, r, http.StatusInternalServerError, err.Error())
+		return
 	}
 
+	resp := &todoResponse{Results: []Item{item}}
 	replyJSONContent(w, r, http.StatusOK, resp)
 }
 
 // deleteHandler deletes item represented by its id
-func deleteHandler(w http.ResponseWriter, r *http.Request, list *todo.List, id int, todoFile string) {
-	list.Delete(id)
-	if err := list.Save(todoFile); err != nil {
+func deleteHandler(w http.ResponseWriter, r *http.Request, store Store, id int, hub *eventHub) {
+	if err := store.Delete(id); err != nil {
+		if errors.Is(err, ErrNoFound) {
+			replyError(w, r, http.StatusNotFound, err.Error())
+			return
+		}
 		replyError(w, r, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	hub.publish(todoEvent{Type: "deleted", Item: Item{ID: id}})
 	replyTextContent(w, r, http.StatusNoContent, "")
 }
 
 // patchHandler completes a specific item
-func pathHandler(w http.ResponseWriter, r *http.Request, list *todo.List, id int, todoFile string) {
+func pathHandler(w http.ResponseWriter, r *http.Request, store Store, id int, hub *eventHub) {
 	q := r.URL.Query()
 
 	if _, ok := q["complete"]; !ok {
@@ -116,17 +172,22 @@ func pathHandler(w http.ResponseWriter, r *http.Request, list *todo.List, id int
 		return
 	}
 
-	list.Complete(id)
-	if err := list.Save(todoFile); err != nil {
+	item, err := store.Complete(id)
+	if err != nil {
+		if errors.Is(err, ErrNoFound) {
+			replyError(w, r, http.StatusNotFound, err.Error())
+			return
+		}
 		replyError(w, r, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	hub.publish(todoEvent{Type: "completed", Item: item})
 	replyTextContent(w, r, http.StatusNoContent, "")
 }
 
 // addHandler adds a new item to the list
-func addHandler(w http.ResponseWriter, r *http.Request, list *todo.List, todoFile string) {
+func addHandler(w http.ResponseWriter, r *http.Request, store Store, hub *eventHub) {
 	item := struct {
 		Task string `json:"task"`
 	}{}
@@ -137,17 +198,20 @@ func addHandler(w http.ResponseWriter, r *http.Request, list *todo.List, todoFil
 		return
 	}
 
-	list.Add(item.Task)
-	if err := list.Save(todoFile); err != nil {
+	added, err := store.Add(item.Task)
+	if err != nil {
 		replyError(w, r, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	hub.publish(todoEvent{Type: "added", Item: added})
 	replyTextContent(w, r, http.StatusCreated, "")
 }
 
-// validateID ensures the ID provided by user is valid
-func validateID(path string, list *todo.List) (int, error) {
+// validateID ensures the ID provided by user is a well-formed positive
+// integer. Whether it actually refers to an existing item is up to the
+// Store, which reports that with ErrNoFound.
+func validateID(path string) (int, error) {
 	id, err := strconv.Atoi(path)
 	if err != nil {
 		return 0, fmt.Errorf("%w: Invalid ID: %s", ErrInvalidData, err)
@@ -157,9 +221,5 @@ func validateID(path string, list *todo.List) (int, error) {
 		return 0, fmt.Errorf("%w: Invalid ID: Less than one", ErrInvalidData)
 	}
 
-	if id > len(*list) {
-		return id, fmt.Errorf("%w: ID %d not found", ErrNoFound, id)
-	}
-
 	return id, nil
 }
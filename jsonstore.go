@@ -0,0 +1,273 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/karapetianash/todo-cli"
+)
+
+// jsonStore is the original file-backed Store, kept for backward
+// compatibility with existing deployments. Every operation reads the whole
+// file, mutates the in-memory list, and rewrites it, all under mu, so
+// traffic is effectively serialized - this is the behavior todoRouter used
+// to implement directly.
+type jsonStore struct {
+	file string
+	mu   sync.Mutex
+}
+
+func newJSONStore(file string) *jsonStore {
+	return &jsonStore{file: file}
+}
+
+func (s *jsonStore) load() (*todo.List, error) {
+	list := &todo.List{}
+	if err := list.Get(s.file); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// rawItem mirrors the JSON shape todo-cli's unexported item type marshals
+// to (it carries no json tags, so its field names are the keys verbatim).
+// toItems round trips through this instead of Item directly, since Item's
+// tags ("task", "created_at", ...) don't line up with todo-cli's field
+// names ("TaskName", "CreatedAt", ...) and would silently decode as zero
+// values.
+type rawItem struct {
+	TaskName    string    `json:"TaskName"`
+	Done        bool      `json:"Done"`
+	CreatedAt   time.Time `json:"CreatedAt"`
+	CompletedAt time.Time `json:"CompletedAt"`
+}
+
+// toItems converts a todo.List into our store-agnostic Items, round
+// tripping through JSON since todo.List's element type is unexported.
+func (s *jsonStore) toItems(list *todo.List) ([]Item, error) {
+	body, err := json.Marshal(list)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw []rawItem
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+
+	items := make([]Item, len(raw))
+	for i, r := range raw {
+		items[i] = Item{
+			ID:        i + 1,
+			Task:      r.TaskName,
+			Done:      r.Done,
+			CreatedAt: r.CreatedAt,
+		}
+
+		if r.Done {
+			completedAt := r.CompletedAt
+			items[i].CompletedAt = &completedAt
+		}
+	}
+
+	return items, nil
+}
+
+func (s *jsonStore) GetAll() ([]Item, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toItems(list)
+}
+
+func (s *jsonStore) GetByID(id int) (Item, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list, err := s.load()
+	if err != nil {
+		return Item{}, err
+	}
+
+	if id < 1 || id > len(*list) {
+		return Item{}, fmt.Errorf("%w: ID %d not found", ErrNoFound, id)
+	}
+
+	items, err := s.toItems(list)
+	if err != nil {
+		return Item{}, err
+	}
+
+	return items[id-1], nil
+}
+
+func (s *jsonStore) Add(task string) (Item, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list, err := s.load()
+	if err != nil {
+		return Item{}, err
+	}
+
+	list.Add(task)
+	if err := list.Save(s.file); err != nil {
+		return Item{}, err
+	}
+
+	items, err := s.toItems(list)
+	if err != nil {
+		return Item{}, err
+	}
+
+	return items[len(items)-1], nil
+}
+
+func (s *jsonStore) Complete(id int) (Item, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list, err := s.load()
+	if err != nil {
+		return Item{}, err
+	}
+
+	if id < 1 || id > len(*list) {
+		return Item{}, fmt.Errorf("%w: ID %d not found", ErrNoFound, id)
+	}
+
+	list.Complete(id)
+	if err := list.Save(s.file); err != nil {
+		return Item{}, err
+	}
+
+	items, err := s.toItems(list)
+	if err != nil {
+		return Item{}, err
+	}
+
+	return items[id-1], nil
+}
+
+func (s *jsonStore) Delete(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	if id < 1 || id > len(*list) {
+		return fmt.Errorf("%w: ID %d not found", ErrNoFound, id)
+	}
+
+	list.Delete(id)
+	return list.Save(s.file)
+}
+
+// Batch applies every op in req against a single loaded list, saving once
+// at the end instead of once per op.
+func (s *jsonStore) Batch(req BatchRequest) ([]BatchOp, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	var ops []BatchOp
+
+	for _, task := range req.Add {
+		list.Add(task)
+
+		items, err := s.toItems(list)
+		if err != nil {
+			return nil, err
+		}
+
+		ops = append(ops, BatchOp{Op: "add", Target: task, Item: items[len(items)-1]})
+	}
+
+	for _, id := range req.Complete {
+		ops = append(ops, s.batchComplete(list, id))
+	}
+
+	ops = append(ops, s.batchDeleteAll(list, req.Delete)...)
+
+	if err := list.Save(s.file); err != nil {
+		return nil, err
+	}
+
+	return ops, nil
+}
+
+// batchDeleteAll deletes every ID in ids against a single snapshot of list.
+// list.Delete shifts every later element down by one position, so deleting
+// in request order would resolve later IDs against positions that already
+// moved (e.g. Delete=[2,4] on [A,B,C,D,E] would delete B, then delete what
+// is now index 4 - E, not D). Processing from the highest ID down avoids
+// that: each delete only ever shifts IDs smaller than the one just removed,
+// and those haven't been resolved yet. Results are returned in request
+// order regardless of the order they were applied in.
+func (s *jsonStore) batchDeleteAll(list *todo.List, ids []int) []BatchOp {
+	order := make([]int, len(ids))
+	for i := range ids {
+		order[i] = i
+	}
+
+	sort.Slice(order, func(a, b int) bool { return ids[order[a]] > ids[order[b]] })
+
+	ops := make([]BatchOp, len(ids))
+	for _, i := range order {
+		ops[i] = s.batchDelete(list, ids[i])
+	}
+
+	return ops
+}
+
+func (s *jsonStore) batchComplete(list *todo.List, id int) BatchOp {
+	target := strconv.Itoa(id)
+
+	if _, err := validateID(target); err != nil {
+		return BatchOp{Op: "complete", Target: target, Err: err}
+	}
+
+	if id > len(*list) {
+		return BatchOp{Op: "complete", Target: target, Err: fmt.Errorf("%w: ID %d not found", ErrNoFound, id)}
+	}
+
+	list.Complete(id)
+
+	items, err := s.toItems(list)
+	if err != nil {
+		return BatchOp{Op: "complete", Target: target, Err: err}
+	}
+
+	return BatchOp{Op: "complete", Target: target, Item: items[id-1]}
+}
+
+func (s *jsonStore) batchDelete(list *todo.List, id int) BatchOp {
+	target := strconv.Itoa(id)
+
+	if _, err := validateID(target); err != nil {
+		return BatchOp{Op: "delete", Target: target, Err: err}
+	}
+
+	if id > len(*list) {
+		return BatchOp{Op: "delete", Target: target, Err: fmt.Errorf("%w: ID %d not found", ErrNoFound, id)}
+	}
+
+	list.Delete(id)
+	return BatchOp{Op: "delete", Target: target, Item: Item{ID: id}}
+}
@@ -1,30 +1,76 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 )
 
 func main() {
 	host := flag.String("h", "localhost", "Server host")
 	port := flag.Int("p", 8080, "Server port")
-	todoFile := flag.String("f", "todoServer.json", "todo JSON file")
+	todoFile := flag.String("f", "todoServer.json", "todo JSON file (used when -store=json)")
+	storeKind := flag.String("store", "json", "Storage backend: json or sqlite")
+	dsn := flag.String("dsn", "file:todos.db", "SQLite data source name (used when -store=sqlite)")
+	shutdownTimeout := flag.Duration("shutdown-timeout", 10*time.Second, "Time to wait for in-flight requests to drain on shutdown")
+	apiKey := flag.String("api-key", os.Getenv("TODOSERVER_API_KEY"), "API key required via 'Authorization: Bearer <key>' (also settable via TODOSERVER_API_KEY); empty disables auth")
+	maxBatchBodyBytes := flag.Int64("batch-max-bytes", 1<<20, "Maximum accepted body size, in bytes, for POST /todo/batch")
 	flag.Parse()
 
+	store, err := newStore(*storeKind, *todoFile, *dsn)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	ready := &readiness{}
+	ready.set(true)
+
 	// We use this server for testing, so we don't worry about connection
 	// closes situation
 	s := &http.Server{
 		Addr:         fmt.Sprintf("%s:%d", *host, *port),
-		Handler:      newMux(*todoFile),
+		Handler:      newMux(store, ready, *apiKey, *maxBatchBodyBytes),
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 10 * time.Second,
 	}
 
-	// TODO: graceful stop
-	if err := s.ListenAndServe(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		if err := s.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			serveErr <- err
+			return
+		}
+		serveErr <- nil
+	}()
+
+	select {
+	case err := <-serveErr:
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	case <-ctx.Done():
+		stop()
+	}
+
+	// Flip readiness first so load balancers stop sending new traffic while
+	// we drain the requests that are already in flight.
+	ready.set(false)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+	defer cancel()
+
+	if err := s.Shutdown(shutdownCtx); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
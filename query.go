@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+// defaultLimit caps the page size returned by GET /todo when the caller
+// doesn't specify one, so a todo file with thousands of items doesn't get
+// dumped in a single response.
+const defaultLimit = 50
+
+// filterItems keeps only items matching the "status" query param, which may
+// be "open", "done", or empty (no filtering).
+func filterItems(items []Item, status string) ([]Item, error) {
+	switch status {
+	case "":
+		return items, nil
+	case "open":
+		return filterByDone(items, false), nil
+	case "done":
+		return filterByDone(items, true), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown status %q: want open or done", ErrInvalidData, status)
+	}
+}
+
+func filterByDone(items []Item, done bool) []Item {
+	out := make([]Item, 0, len(items))
+	for _, item := range items {
+		if item.Done == done {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// sortItems orders items in place by the "sort" query param: "created",
+// "completed", or "task". An empty value leaves the store's natural order.
+func sortItems(items []Item, sortBy string) error {
+	switch sortBy {
+	case "":
+		return nil
+	case "created":
+		sort.Slice(items, func(i, j int) bool { return items[i].CreatedAt.Before(items[j].CreatedAt) })
+	case "completed":
+		sort.Slice(items, func(i, j int) bool { return completedBefore(items[i], items[j]) })
+	case "task":
+		sort.Slice(items, func(i, j int) bool { return items[i].Task < items[j].Task })
+	default:
+		return fmt.Errorf("%w: unknown sort %q: want created, completed or task", ErrInvalidData, sortBy)
+	}
+
+	return nil
+}
+
+// completedBefore orders by CompletedAt, pushing not-yet-completed items
+// (nil CompletedAt) to the end.
+func completedBefore(a, b Item) bool {
+	switch {
+	case a.CompletedAt == nil:
+		return false
+	case b.CompletedAt == nil:
+		return true
+	default:
+		return a.CompletedAt.Before(*b.CompletedAt)
+	}
+}
+
+// paginationParams reads "limit" and "offset" from the query string,
+// defaulting limit to defaultLimit and offset to 0.
+func paginationParams(q url.Values) (limit, offset int, err error) {
+	limit = defaultLimit
+	if v := q.Get("limit"); v != "" {
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit < 0 {
+			return 0, 0, fmt.Errorf("%w: invalid limit %q", ErrInvalidData, v)
+		}
+	}
+
+	if v := q.Get("offset"); v != "" {
+		offset, err = strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			return 0, 0, fmt.Errorf("%w: invalid offset %q", ErrInvalidData, v)
+		}
+	}
+
+	return limit, offset, nil
+}
+
+// paginate slices items to [offset:offset+limit], returning the offset of
+// the next page or -1 if there isn't one. A limit of 0 means "no limit".
+func paginate(items []Item, limit, offset int) (page []Item, nextOffset int) {
+	if offset >= len(items) {
+		return []Item{}, -1
+	}
+
+	end := len(items)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+
+	page = items[offset:end]
+	if end < len(items) {
+		return page, end
+	}
+
+	return page, -1
+}
+
+// nextLink builds a Link: rel="next" header value pointing at the next page
+// of the same /todo request.
+func nextLink(r *http.Request, limit, offset int) string {
+	q := r.URL.Query()
+	q.Set("limit", strconv.Itoa(limit))
+	q.Set("offset", strconv.Itoa(offset))
+
+	u := url.URL{Path: "/todo" + r.URL.Path, RawQuery: q.Encode()}
+	return fmt.Sprintf(`<%s>; rel="next"`, u.String())
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// TestGetAllHandlerPaginationSortFilter seeds five items and walks GET /todo
+// through the status/sort/limit/offset query params, including the invalid
+// values that should 400 rather than fall back to defaults silently.
+func TestGetAllHandlerPaginationSortFilter(t *testing.T) {
+	h := newTestMux(t)
+
+	for i := 1; i <= 5; i++ {
+		rec := doRequest(h, http.MethodPost, "/todo", fmt.Sprintf(`{"task":"task-%d"}`, i))
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("POST /todo: want %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body)
+		}
+	}
+
+	for _, id := range []string{"2", "4"} {
+		rec := doRequest(h, http.MethodPatch, "/todo/"+id+"?complete", "")
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("PATCH /todo/%s?complete: want %d, got %d: %s", id, http.StatusNoContent, rec.Code, rec.Body)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+	}{
+		{"no params", "", http.StatusOK},
+		{"filter open", "?status=open", http.StatusOK},
+		{"filter done", "?status=done", http.StatusOK},
+		{"invalid filter", "?status=bogus", http.StatusBadRequest},
+		{"sort task", "?sort=task", http.StatusOK},
+		{"sort created", "?sort=created", http.StatusOK},
+		{"sort completed", "?sort=completed", http.StatusOK},
+		{"invalid sort", "?sort=bogus", http.StatusBadRequest},
+		{"limit and offset", "?limit=2&offset=1", http.StatusOK},
+		{"negative limit", "?limit=-1", http.StatusBadRequest},
+		{"non-numeric limit", "?limit=abc", http.StatusBadRequest},
+		{"negative offset", "?offset=-1", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := doRequest(h, http.MethodGet, "/todo"+tt.query, "")
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("GET /todo%s: want %d, got %d: %s", tt.query, tt.wantStatus, rec.Code, rec.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestErrorEnvelopeCarriesRequestID checks that a 404 comes back as the
+// structured apiErrorBody, and that its request_id matches the X-Request-ID
+// header on the same response, the correlation the middleware exists for.
+func TestErrorEnvelopeCarriesRequestID(t *testing.T) {
+	h := newTestMux(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/todo/999", nil)
+	req.Header.Set("Authorization", "Bearer "+testAPIKey)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("GET /todo/999: want %d, got %d: %s", http.StatusNotFound, rec.Code, rec.Body)
+	}
+
+	headerID := rec.Header().Get("X-Request-ID")
+	if headerID == "" {
+		t.Fatal("response is missing the X-Request-ID header")
+	}
+
+	var body apiErrorBody
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decoding error envelope: %v: %s", err, rec.Body)
+	}
+
+	if body.Error.Code != "not_found" {
+		t.Fatalf("error.code: want %q, got %q", "not_found", body.Error.Code)
+	}
+	if body.Error.RequestID != headerID {
+		t.Fatalf("error.request_id %q does not match X-Request-ID header %q", body.Error.RequestID, headerID)
+	}
+}
+
+// TestRequestIDHonorsCallerSuppliedHeader ensures an incoming X-Request-ID
+// (e.g. set by an upstream proxy) is echoed back rather than replaced.
+func TestRequestIDHonorsCallerSuppliedHeader(t *testing.T) {
+	h := newTestMux(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/todo", nil)
+	req.Header.Set("Authorization", "Bearer "+testAPIKey)
+	req.Header.Set("X-Request-ID", "caller-supplied-id")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("X-Request-ID"); got != "caller-supplied-id" {
+		t.Fatalf("X-Request-ID: want %q, got %q", "caller-supplied-id", got)
+	}
+}
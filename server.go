@@ -4,21 +4,56 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
-	"sync"
+	"sync/atomic"
 )
 
-func newMux(todoFile string) http.Handler {
+// readiness tracks whether the server should be considered ready to take
+// traffic. It flips to false while the server is draining in-flight
+// requests during a graceful shutdown.
+type readiness struct {
+	ready atomic.Bool
+}
+
+func (r *readiness) set(v bool) {
+	r.ready.Store(v)
+}
+
+func (r *readiness) isReady() bool {
+	return r.ready.Load()
+}
+
+func newMux(store Store, ready *readiness, apiKey string, maxBatchBodyBytes int64) http.Handler {
 	m := http.NewServeMux()
-	mu := &sync.Mutex{}
 
 	m.HandleFunc("/", rootHandler)
+	m.HandleFunc("/healthz", healthzHandler)
+	m.Handle("/readyz", readyzHandler(ready))
 
-	t := todoRouter(todoFile, mu)
+	hub := newEventHub()
+	t := todoRouter(store, hub, maxBatchBodyBytes)
 
 	m.Handle("/todo", http.StripPrefix("/todo", t))
 	m.Handle("/todo/", http.StripPrefix("/todo/", t)) // same result with or without the trailing slash
 
-	return m
+	return requestIDMiddleware(authMiddleware(apiKey, m))
+}
+
+// healthzHandler reports liveness: if the process can answer at all, it's healthy.
+func healthzHandler(w http.ResponseWriter, r *http.Request) {
+	replyTextContent(w, r, http.StatusOK, "ok")
+}
+
+// readyzHandler reports readiness: it flips to unavailable while the server
+// is draining in-flight requests during shutdown, so load balancers stop
+// routing new traffic.
+func readyzHandler(ready *readiness) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !ready.isReady() {
+			replyTextContent(w, r, http.StatusServiceUnavailable, "not ready")
+			return
+		}
+		replyTextContent(w, r, http.StatusOK, "ok")
+	}
 }
 
 // replyTextContent replies with text content
@@ -41,8 +76,57 @@ func replyJSONContent(w http.ResponseWriter, r *http.Request, status int, resp *
 	w.Write(body)
 }
 
-// replyError logs an error and reply to the request with an appropriate HTTP error
+// replyError logs an error, tagged with the request's ID for correlation,
+// and replies with a structured JSON error body carrying that same ID.
 func replyError(w http.ResponseWriter, r *http.Request, status int, message string) {
-	log.Printf("%s %s: Error: %d %s", r.URL, r.Method, status, message)
-	http.Error(w, http.StatusText(status), status)
+	reqID := requestIDFromContext(r.Context())
+	log.Printf("[%s] %s %s: Error: %d %s", reqID, r.Method, r.URL, status, message)
+
+	if message == "" {
+		message = http.StatusText(status)
+	}
+
+	writeErrorJSON(w, status, errorCode(status), message, reqID)
+}
+
+// apiErrorBody is the structured JSON error envelope returned to clients.
+type apiErrorBody struct {
+	Error apiErrorDetail `json:"error"`
+}
+
+type apiErrorDetail struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+func writeErrorJSON(w http.ResponseWriter, status int, code, message, requestID string) {
+	body := apiErrorBody{Error: apiErrorDetail{Code: code, Message: message, RequestID: requestID}}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(payload)
+}
+
+// errorCode maps an HTTP status to the stable machine-readable code clients
+// can switch on without parsing the human-readable message.
+func errorCode(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return "invalid_data"
+	case http.StatusUnauthorized:
+		return "unauthorized"
+	case http.StatusNotFound:
+		return "not_found"
+	case http.StatusMethodNotAllowed:
+		return "method_not_allowed"
+	default:
+		return "internal_error"
+	}
 }
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testAPIKey = "test-key"
+
+func newTestMux(t *testing.T) http.Handler {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "todoServer.json")
+	store := newJSONStore(file)
+	ready := &readiness{}
+	ready.set(true)
+
+	return newMux(store, ready, testAPIKey, 1<<20)
+}
+
+func doRequest(h http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+testAPIKey)
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestTodoRouterRequiresAPIKey(t *testing.T) {
+	h := newTestMux(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/todo", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("GET /todo without a key: want %d, got %d", http.StatusUnauthorized, rec.Code)
+	}
+}
+
+// TestReadyzBypassesAPIKey ensures /readyz stays reachable without an API
+// key, same as /healthz, so an LB readiness probe doesn't need credentials.
+func TestReadyzBypassesAPIKey(t *testing.T) {
+	h := newTestMux(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /readyz without a key: want %d, got %d", http.StatusOK, rec.Code)
+	}
+}
+
+// TestTodoRouterAllFourVerbs walks an item through POST, GET, PATCH
+// (complete), and DELETE, the four verbs the todo router supports.
+func TestTodoRouterAllFourVerbs(t *testing.T) {
+	h := newTestMux(t)
+
+	rec := doRequest(h, http.MethodPost, "/todo", `{"task":"write tests"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST /todo: want %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body)
+	}
+
+	rec = doRequest(h, http.MethodGet, "/todo", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /todo: want %d, got %d: %s", http.StatusOK, rec.Code, rec.Body)
+	}
+	if !strings.Contains(rec.Body.String(), "write tests") {
+		t.Fatalf("GET /todo: added item missing from response: %s", rec.Body)
+	}
+
+	rec = doRequest(h, http.MethodPatch, "/todo/1?complete", "")
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("PATCH /todo/1?complete: want %d, got %d: %s", http.StatusNoContent, rec.Code, rec.Body)
+	}
+
+	rec = doRequest(h, http.MethodGet, "/todo/1", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /todo/1: want %d, got %d: %s", http.StatusOK, rec.Code, rec.Body)
+	}
+	if !strings.Contains(rec.Body.String(), `"done":true`) {
+		t.Fatalf("GET /todo/1: item not marked done: %s", rec.Body)
+	}
+
+	rec = doRequest(h, http.MethodDelete, "/todo/1", "")
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("DELETE /todo/1: want %d, got %d: %s", http.StatusNoContent, rec.Code, rec.Body)
+	}
+
+	rec = doRequest(h, http.MethodGet, "/todo", "")
+	if strings.Contains(rec.Body.String(), "write tests") {
+		t.Fatalf("GET /todo: deleted item still present: %s", rec.Body)
+	}
+}
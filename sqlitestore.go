@@ -0,0 +1,209 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// sqliteStore is a SQLite-backed Store. Unlike jsonStore it doesn't need a
+// process-wide mutex: SQLite serializes writes internally and readers don't
+// block each other, so concurrent GETs no longer queue up behind one
+// another the way they do against the JSON file.
+type sqliteStore struct {
+	db *sql.DB
+}
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS todos (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	task TEXT NOT NULL,
+	done BOOLEAN NOT NULL DEFAULT 0,
+	created_at DATETIME NOT NULL,
+	completed_at DATETIME
+);`
+
+func newSQLiteStore(dsn string) (*sqliteStore, error) {
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &sqliteStore{db: db}, nil
+}
+
+// execer is satisfied by both *sql.DB and *sql.Tx, so the single-item
+// queries below can run standalone or as part of a Batch transaction.
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+func (s *sqliteStore) GetAll() ([]Item, error) {
+	rows, err := s.db.Query(`SELECT id, task, done, created_at, completed_at FROM todos ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []Item
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func (s *sqliteStore) GetByID(id int) (Item, error) {
+	return getItem(s.db, id)
+}
+
+func (s *sqliteStore) Add(task string) (Item, error) {
+	return addItem(s.db, task)
+}
+
+func (s *sqliteStore) Complete(id int) (Item, error) {
+	return completeItem(s.db, id)
+}
+
+func (s *sqliteStore) Delete(id int) error {
+	return deleteItem(s.db, id)
+}
+
+// Batch applies every op in req inside a single transaction.
+func (s *sqliteStore) Batch(req BatchRequest) ([]BatchOp, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var ops []BatchOp
+
+	for _, task := range req.Add {
+		item, err := addItem(tx, task)
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, BatchOp{Op: "add", Target: task, Item: item})
+	}
+
+	for _, id := range req.Complete {
+		target := strconv.Itoa(id)
+		item, err := completeItem(tx, id)
+		ops = append(ops, BatchOp{Op: "complete", Target: target, Item: item, Err: err})
+	}
+
+	for _, id := range req.Delete {
+		target := strconv.Itoa(id)
+		ops = append(ops, BatchOp{Op: "delete", Target: target, Item: Item{ID: id}, Err: deleteItem(tx, id)})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return ops, nil
+}
+
+func getItem(e execer, id int) (Item, error) {
+	row := e.QueryRow(`SELECT id, task, done, created_at, completed_at FROM todos WHERE id = ?`, id)
+
+	item, err := scanItem(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Item{}, fmt.Errorf("%w: ID %d not found", ErrNoFound, id)
+		}
+		return Item{}, err
+	}
+
+	return item, nil
+}
+
+func addItem(e execer, task string) (Item, error) {
+	now := time.Now()
+
+	res, err := e.Exec(`INSERT INTO todos (task, done, created_at) VALUES (?, 0, ?)`, task, now)
+	if err != nil {
+		return Item{}, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return Item{}, err
+	}
+
+	return Item{ID: int(id), Task: task, CreatedAt: now}, nil
+}
+
+func completeItem(e execer, id int) (Item, error) {
+	now := time.Now()
+
+	res, err := e.Exec(`UPDATE todos SET done = 1, completed_at = ? WHERE id = ?`, now, id)
+	if err != nil {
+		return Item{}, err
+	}
+
+	if err := checkAffected(res, id); err != nil {
+		return Item{}, err
+	}
+
+	return getItem(e, id)
+}
+
+func deleteItem(e execer, id int) error {
+	res, err := e.Exec(`DELETE FROM todos WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+
+	return checkAffected(res, id)
+}
+
+func checkAffected(res sql.Result, id int) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return fmt.Errorf("%w: ID %d not found", ErrNoFound, id)
+	}
+
+	return nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanItem(row rowScanner) (Item, error) {
+	var (
+		item        Item
+		completedAt sql.NullTime
+	)
+
+	if err := row.Scan(&item.ID, &item.Task, &item.Done, &item.CreatedAt, &completedAt); err != nil {
+		return Item{}, err
+	}
+
+	if completedAt.Valid {
+		item.CompletedAt = &completedAt.Time
+	}
+
+	return item, nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestSQLiteMux is the sqliteStore counterpart of newTestMux, so the
+// same handler suite can be exercised against either backend.
+func newTestSQLiteMux(t *testing.T) http.Handler {
+	t.Helper()
+
+	dsn := filepath.Join(t.TempDir(), "todoServer.db")
+	store, err := newSQLiteStore(dsn)
+	if err != nil {
+		t.Fatalf("newSQLiteStore: %v", err)
+	}
+
+	ready := &readiness{}
+	ready.set(true)
+
+	return newMux(store, ready, testAPIKey, 1<<20)
+}
+
+// TestSQLiteStoreAllFourVerbs is a smoke test running the same add/list/
+// complete/delete flow as TestTodoRouterAllFourVerbs, but against
+// sqliteStore instead of jsonStore.
+func TestSQLiteStoreAllFourVerbs(t *testing.T) {
+	h := newTestSQLiteMux(t)
+
+	rec := doRequest(h, http.MethodPost, "/todo", `{"task":"write tests"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST /todo: want %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body)
+	}
+
+	rec = doRequest(h, http.MethodGet, "/todo", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /todo: want %d, got %d: %s", http.StatusOK, rec.Code, rec.Body)
+	}
+	if !strings.Contains(rec.Body.String(), "write tests") {
+		t.Fatalf("GET /todo: added item missing from response: %s", rec.Body)
+	}
+
+	rec = doRequest(h, http.MethodPatch, "/todo/1?complete", "")
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("PATCH /todo/1?complete: want %d, got %d: %s", http.StatusNoContent, rec.Code, rec.Body)
+	}
+
+	rec = doRequest(h, http.MethodGet, "/todo/1", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /todo/1: want %d, got %d: %s", http.StatusOK, rec.Code, rec.Body)
+	}
+	if !strings.Contains(rec.Body.String(), `"done":true`) {
+		t.Fatalf("GET /todo/1: item not marked done: %s", rec.Body)
+	}
+
+	rec = doRequest(h, http.MethodDelete, "/todo/1", "")
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("DELETE /todo/1: want %d, got %d: %s", http.StatusNoContent, rec.Code, rec.Body)
+	}
+
+	rec = doRequest(h, http.MethodGet, "/todo", "")
+	if strings.Contains(rec.Body.String(), "write tests") {
+		t.Fatalf("GET /todo: deleted item still present: %s", rec.Body)
+	}
+}
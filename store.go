@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// Item is the store-agnostic representation of a to-do entry returned to
+// clients, independent of whether it's backed by the JSON file or SQLite.
+type Item struct {
+	ID          int        `json:"id"`
+	Task        string     `json:"task"`
+	Done        bool       `json:"done"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// Store is the persistence layer used by the HTTP handlers. It replaces the
+// previous pattern of handlers reading and rewriting a whole todoFile under
+// a process-wide mutex on every request, so a backend can pick its own
+// concurrency story (see sqliteStore, which drops the mutex entirely).
+type Store interface {
+	GetAll() ([]Item, error)
+	GetByID(id int) (Item, error)
+	Add(task string) (Item, error)
+	Complete(id int) (Item, error)
+	Delete(id int) error
+
+	// Batch applies every add/complete/delete in req under a single
+	// lock/save (jsonStore) or transaction (sqliteStore) instead of one
+	// per op. The returned error is only for failures affecting the whole
+	// batch (e.g. the file couldn't be saved); per-item failures such as
+	// an unknown ID are reported on the corresponding BatchOp.Err so one
+	// bad ID doesn't sink the rest of the batch.
+	Batch(req BatchRequest) ([]BatchOp, error)
+}
+
+// BatchRequest is the payload for POST /todo/batch.
+type BatchRequest struct {
+	Add      []string `json:"add,omitempty"`
+	Complete []int    `json:"complete,omitempty"`
+	Delete   []int    `json:"delete,omitempty"`
+}
+
+// BatchOp is the outcome of one operation within a Batch call.
+type BatchOp struct {
+	Op     string // "add", "complete", or "delete"
+	Target string // the task text for "add", the ID for "complete"/"delete"
+	Item   Item
+	Err    error
+}
+
+// newStore builds the Store selected by kind ("json" or "sqlite").
+func newStore(kind, todoFile, dsn string) (Store, error) {
+	switch kind {
+	case "json":
+		return newJSONStore(todoFile), nil
+	case "sqlite":
+		return newSQLiteStore(dsn)
+	default:
+		return nil, fmt.Errorf("unknown -store %q: want json or sqlite", kind)
+	}
+}